@@ -25,9 +25,11 @@ import (
 )
 
 type bondingCollector struct {
-	fs             sysfs.FS
-	slaves, active typedDesc
-	logger         log.Logger
+	fs                                            sysfs.FS
+	slaves, active                                typedDesc
+	slaveMiiStatus, activeSlave, slaveLinkFailures typedDesc
+	info                                           typedDesc
+	logger                                         log.Logger
 }
 
 func init() {
@@ -53,6 +55,26 @@ func NewBondingCollector(logger log.Logger) (Collector, error) {
 			"Number of active slaves per bonding interface.",
 			[]string{"master"}, nil,
 		), prometheus.GaugeValue},
+		slaveMiiStatus: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "bonding", "slave_mii_status"),
+			"Status of a bonding slave's MII link, 1 if 'up', 0 otherwise.",
+			[]string{"master", "slave"}, nil,
+		), prometheus.GaugeValue},
+		activeSlave: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "bonding", "active_slave"),
+			"Whether the slave is the active slave of its master, 1 if active, 0 otherwise.",
+			[]string{"master", "slave"}, nil,
+		), prometheus.GaugeValue},
+		slaveLinkFailures: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "bonding", "slave_link_failures_total"),
+			"Number of times the link of a bonding slave has failed.",
+			[]string{"master", "slave"}, nil,
+		), prometheus.CounterValue},
+		info: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "bonding", "info"),
+			"Non-numeric data about a bonding interface, value is always 1.",
+			[]string{"master", "mode", "xmit_hash_policy", "primary"}, nil,
+		), prometheus.GaugeValue},
 		logger: logger,
 	}, nil
 }
@@ -70,12 +92,24 @@ func (c *bondingCollector) Update(ch chan<- prometheus.Metric) error {
 	for master, bondingInfo := range bondingStats {
 		ch <- c.slaves.mustNewConstMetric(float64(len(bondingInfo.Slaves)), master)
 		active := 0
-		for _, slave := range bondingInfo.Slaves {
+		for name, slave := range bondingInfo.Slaves {
 			if slave.MiiStatus == 1 {
 				active++
 			}
+			ch <- c.slaveMiiStatus.mustNewConstMetric(float64(slave.MiiStatus), master, name)
+
+			isActive := 0.0
+			if name == bondingInfo.ActiveSlave {
+				isActive = 1.0
+			}
+			ch <- c.activeSlave.mustNewConstMetric(isActive, master, name)
+
+			if slave.LinkFailureCount != nil {
+				ch <- c.slaveLinkFailures.mustNewConstMetric(float64(*slave.LinkFailureCount), master, name)
+			}
 		}
 		ch <- c.active.mustNewConstMetric(float64(active), master)
+		ch <- c.info.mustNewConstMetric(1, master, bondingInfo.Mode, bondingInfo.XmitHashPolicy, bondingInfo.Primary)
 	}
 	return nil
 }
@@ -0,0 +1,83 @@
+// Copyright 2020 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// deviceFilter holds a single compiled regexp used to either include or
+// exclude net devices by name, depending on which flag populated it.
+type deviceFilter struct {
+	ignoredPattern *regexp.Regexp
+}
+
+func newDeviceFilter(patternString string) (f deviceFilter) {
+	if patternString != "" {
+		f.ignoredPattern = regexp.MustCompile(patternString)
+	}
+	return
+}
+
+// ignored reports whether name matches the configured pattern.
+func (f *deviceFilter) ignored(name string) bool {
+	return f.ignoredPattern != nil && f.ignoredPattern.MatchString(name)
+}
+
+// invalidMetricNameChars matches everything that isn't allowed in a
+// Prometheus metric name component.
+var invalidMetricNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// sanitizeMetricName makes an arbitrary driver-supplied string (e.g. an
+// ethtool stat name) safe to use as part of a Prometheus metric name:
+// disallowed characters are replaced with "_", and a leading digit is
+// prefixed with "_" since metric names may not start with one.
+func sanitizeMetricName(name string) string {
+	name = invalidMetricNameChars.ReplaceAllString(name, "_")
+	if name == "" {
+		return "_"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// newNetclassDeviceFilter builds the deviceFilter shared by the netclass and
+// netclass_statistics collectors from the
+// --collector.netclass.device-exclude/--collector.netclass.device-include
+// flags, rejecting the case where both are set so the two collectors can't
+// silently disagree on behavior for the same flag pair. The returned bool
+// reports whether the filter should be interpreted as an include pattern.
+func newNetclassDeviceFilter(logger log.Logger, exclude, include string) (deviceFilter, bool, error) {
+	if exclude != "" && include != "" {
+		return deviceFilter{}, false, errors.New("collector.netclass.device-exclude & collector.netclass.device-include are mutually exclusive")
+	}
+
+	var filter deviceFilter
+	if exclude != "" {
+		level.Info(logger).Log("msg", "Parsed flag --collector.netclass.device-exclude", "flag", exclude)
+		filter = newDeviceFilter(exclude)
+	}
+	if include != "" {
+		level.Info(logger).Log("msg", "Parsed flag --collector.netclass.device-include", "flag", include)
+		filter = newDeviceFilter(include)
+	}
+
+	return filter, include != "", nil
+}
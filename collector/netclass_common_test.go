@@ -0,0 +1,38 @@
+// Copyright 2020 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import "testing"
+
+func TestSanitizeMetricName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"already valid", "rx_bytes", "rx_bytes"},
+		{"dashes and dots", "rx-bytes.dropped", "rx_bytes_dropped"},
+		{"brackets and spaces", "[0] Tx Errors", "_0__Tx_Errors"},
+		{"leading digit", "64_octets", "_64_octets"},
+		{"empty string", "", "_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeMetricName(tt.in); got != tt.want {
+				t.Errorf("sanitizeMetricName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
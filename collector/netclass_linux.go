@@ -0,0 +1,207 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !nonetclass
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs/sysfs"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type netClassCollector struct {
+	fs                    sysfs.FS
+	subsystem             string
+	deviceFilter          deviceFilter
+	deviceFilterIsInclude bool
+	metricDescs           map[string]*prometheus.Desc
+	logger                log.Logger
+}
+
+var (
+	netclassDeviceExclude = kingpin.Flag(
+		"collector.netclass.device-exclude",
+		"Regexp of net devices to exclude (mutually exclusive to device-include).",
+	).Default("").String()
+	netclassDeviceInclude = kingpin.Flag(
+		"collector.netclass.device-include",
+		"Regexp of net devices to include (mutually exclusive to device-exclude).",
+	).Default("").String()
+)
+
+func init() {
+	registerCollector("netclass", defaultEnabled, NewNetClassCollector)
+}
+
+// NewNetClassCollector returns a new Collector exposing network class stats.
+func NewNetClassCollector(logger log.Logger) (Collector, error) {
+	fs, err := sysfs.NewFS(*sysPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sysfs: %w", err)
+	}
+
+	filter, isInclude, err := newNetclassDeviceFilter(logger, *netclassDeviceExclude, *netclassDeviceInclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &netClassCollector{
+		fs:                    fs,
+		subsystem:             "network",
+		deviceFilter:          filter,
+		deviceFilterIsInclude: isInclude,
+		metricDescs:           map[string]*prometheus.Desc{},
+		logger:                logger,
+	}, nil
+}
+
+// Update implements Collector and exposes network class metrics, respecting
+// the configured device include/exclude filter.
+func (c *netClassCollector) Update(ch chan<- prometheus.Metric) error {
+	netClass, err := c.getNetClassInfo()
+	if err != nil {
+		return fmt.Errorf("could not get net class info: %w", err)
+	}
+	for _, ifaceInfo := range netClass {
+		upDesc := c.subsystemDesc("up", "Value is 1 if operstate is 'up', 0 otherwise.", []string{"device"})
+		upValue := 0.0
+		if ifaceInfo.OperState == "up" {
+			upValue = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, upValue, ifaceInfo.Name)
+
+		c.pushMetric(ch, "address_assign_type", ifaceInfo.AddrAssignType, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "carrier", ifaceInfo.Carrier, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "carrier_changes_total", ifaceInfo.CarrierChanges, ifaceInfo.Name, prometheus.CounterValue)
+		c.pushMetric(ch, "carrier_up_changes_total", ifaceInfo.CarrierUpCount, ifaceInfo.Name, prometheus.CounterValue)
+		c.pushMetric(ch, "carrier_down_changes_total", ifaceInfo.CarrierDownCount, ifaceInfo.Name, prometheus.CounterValue)
+		c.pushMetric(ch, "device_id", ifaceInfo.DevID, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "dormant", ifaceInfo.Dormant, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "flags", ifaceInfo.Flags, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "iface_id", ifaceInfo.IfIndex, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "iface_link", ifaceInfo.IfLink, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "iface_link_mode", ifaceInfo.LinkMode, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "mtu_bytes", ifaceInfo.MTU, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "name_assign_type", ifaceInfo.NameAssignType, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "net_dev_group", ifaceInfo.NetDevGroup, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "protocol_type", ifaceInfo.Type, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "speed_bytes", ifaceInfo.Speed, ifaceInfo.Name, prometheus.GaugeValue)
+		c.pushMetric(ch, "transmit_queue_length", ifaceInfo.TxQueueLen, ifaceInfo.Name, prometheus.GaugeValue)
+
+		ethtoolInfo, err := c.fs.NetClassEthtool(ifaceInfo.Name)
+		if err != nil {
+			level.Debug(c.logger).Log("msg", "failed to read ethtool info", "device", ifaceInfo.Name, "err", err)
+			ethtoolInfo = &sysfs.NetClassEthtoolInfo{}
+		}
+
+		infoDesc := c.subsystemDesc("info", "Non-numeric data from /sys/class/net/<iface> and ethtool, value is always 1.",
+			[]string{"device", "address", "broadcast", "duplex", "operstate", "ifalias", "driver", "firmware", "bus_info"})
+		ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1.0,
+			ifaceInfo.Name, ifaceInfo.Address, ifaceInfo.Broadcast, ifaceInfo.Duplex, ifaceInfo.OperState, ifaceInfo.IfAlias,
+			ethtoolInfo.Driver, ethtoolInfo.FirmwareVersion, ethtoolInfo.BusInfo)
+
+		if ethtoolInfo.Driver != "" {
+			autonegDesc := c.subsystemDesc("autonegotiate", "Autonegotiation status reported by ethtool, 1 if enabled.", []string{"device"})
+			autoneg := 0.0
+			if ethtoolInfo.AutoNegotiate {
+				autoneg = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(autonegDesc, prometheus.GaugeValue, autoneg, ifaceInfo.Name)
+		}
+
+		if ethtoolInfo.AdvertisedSpeed != nil {
+			advSpeedDesc := c.subsystemDesc("advertised_speed_bytes", "Maximum advertised link speed in bytes/sec, from ethtool.", []string{"device"})
+			ch <- prometheus.MustNewConstMetric(advSpeedDesc, prometheus.GaugeValue, float64(*ethtoolInfo.AdvertisedSpeed), ifaceInfo.Name)
+		}
+
+		for stat, value := range ethtoolInfo.Stats {
+			statDesc := c.subsystemDesc("ethtool_stat_"+sanitizeMetricName(stat), fmt.Sprintf("Driver-reported ethtool statistic %q.", stat), []string{"device"})
+			ch <- prometheus.MustNewConstMetric(statDesc, prometheus.CounterValue, float64(value), ifaceInfo.Name)
+		}
+
+		sriov, err := c.fs.NetClassSRIOV(ifaceInfo.Name)
+		if err != nil {
+			level.Debug(c.logger).Log("msg", "failed to read SR-IOV info", "device", ifaceInfo.Name, "err", err)
+			sriov = nil
+		}
+		if sriov != nil {
+			totalDesc := c.subsystemDesc("sriov_vfs_total", "Maximum number of SR-IOV virtual functions supported by this device.", []string{"device"})
+			ch <- prometheus.MustNewConstMetric(totalDesc, prometheus.GaugeValue, float64(sriov.TotalVFs), ifaceInfo.Name)
+
+			numVFsDesc := c.subsystemDesc("sriov_vfs_numvfs", "Number of SR-IOV virtual functions currently configured on this device.", []string{"device"})
+			ch <- prometheus.MustNewConstMetric(numVFsDesc, prometheus.GaugeValue, float64(sriov.NumVFs), ifaceInfo.Name)
+
+			vfInfoDesc := c.subsystemDesc("sriov_vf_info", "Non-numeric data about an SR-IOV virtual function, value is always 1.",
+				[]string{"device", "vf", "pci_addr", "mac", "vlan"})
+			for _, vf := range sriov.VFs {
+				vlan := ""
+				if vf.VLAN != nil {
+					vlan = fmt.Sprintf("%d", *vf.VLAN)
+				}
+				ch <- prometheus.MustNewConstMetric(vfInfoDesc, prometheus.GaugeValue, 1.0,
+					ifaceInfo.Name, fmt.Sprintf("%d", vf.Index), vf.PCIAddr, vf.MAC, vlan)
+			}
+		}
+	}
+	return nil
+}
+
+// getNetClassInfo reads /sys/class/net, restricted to devices that pass the
+// configured include/exclude filter, to avoid the cost of parsing excluded
+// interfaces.
+func (c *netClassCollector) getNetClassInfo() (sysfs.NetClass, error) {
+	netClass := sysfs.NetClass{}
+	devices, err := c.fs.NetClassDevices()
+	if err != nil {
+		return netClass, err
+	}
+
+	for _, device := range devices {
+		if c.deviceFilter.ignored(device) != c.deviceFilterIsInclude {
+			continue
+		}
+
+		interfaceClass, err := c.fs.NetClassByIface(device)
+		if err != nil {
+			level.Debug(c.logger).Log("msg", "failed to read net class info", "device", device, "err", err)
+			continue
+		}
+		netClass[device] = *interfaceClass
+	}
+
+	return netClass, nil
+}
+
+func (c *netClassCollector) subsystemDesc(name, help string, labels []string) *prometheus.Desc {
+	key := name
+	if desc, ok := c.metricDescs[key]; ok {
+		return desc
+	}
+	desc := prometheus.NewDesc(prometheus.BuildFQName(namespace, c.subsystem, name), help, labels, nil)
+	c.metricDescs[key] = desc
+	return desc
+}
+
+func (c *netClassCollector) pushMetric(ch chan<- prometheus.Metric, name string, value *int64, devName string, valueType prometheus.ValueType) {
+	if value == nil {
+		return
+	}
+	desc := c.subsystemDesc(name, fmt.Sprintf("Network device property: %s", name), []string{"device"})
+	ch <- prometheus.MustNewConstMetric(desc, valueType, float64(*value), devName)
+}
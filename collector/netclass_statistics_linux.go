@@ -0,0 +1,99 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !nonetclassstatistics
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs/sysfs"
+)
+
+type netClassStatisticsCollector struct {
+	fs                    sysfs.FS
+	subsystem             string
+	deviceFilter          deviceFilter
+	deviceFilterIsInclude bool
+	metricDescs           map[string]*prometheus.Desc
+	logger                log.Logger
+}
+
+func init() {
+	registerCollector("netclass_statistics", defaultDisabled, NewNetClassStatisticsCollector)
+}
+
+// NewNetClassStatisticsCollector returns a new Collector exposing
+// /sys/class/net/<iface>/statistics/* as counters. This is an alternative to
+// the procfs-derived netdev counters, useful when a driver's /proc/net/dev
+// values are known to be unreliable.
+func NewNetClassStatisticsCollector(logger log.Logger) (Collector, error) {
+	fs, err := sysfs.NewFS(*sysPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sysfs: %w", err)
+	}
+
+	filter, isInclude, err := newNetclassDeviceFilter(logger, *netclassDeviceExclude, *netclassDeviceInclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &netClassStatisticsCollector{
+		fs:                    fs,
+		subsystem:             "network",
+		deviceFilter:          filter,
+		deviceFilterIsInclude: isInclude,
+		metricDescs:           map[string]*prometheus.Desc{},
+		logger:                logger,
+	}, nil
+}
+
+// Update implements Collector. It is subject to the same
+// --collector.netclass.device-include/--collector.netclass.device-exclude
+// filters as the netclass collector.
+func (c *netClassStatisticsCollector) Update(ch chan<- prometheus.Metric) error {
+	devices, err := c.fs.NetClassDevices()
+	if err != nil {
+		return fmt.Errorf("could not list net devices: %w", err)
+	}
+
+	for _, device := range devices {
+		if c.deviceFilter.ignored(device) != c.deviceFilterIsInclude {
+			continue
+		}
+
+		stats, err := c.fs.NetClassStatistics(device)
+		if err != nil {
+			level.Debug(c.logger).Log("msg", "failed to read net class statistics", "device", device, "err", err)
+			continue
+		}
+
+		for name, value := range stats {
+			desc, ok := c.metricDescs[name]
+			if !ok {
+				desc = prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, c.subsystem, name+"_total"),
+					fmt.Sprintf("Network device statistic %s.", name),
+					[]string{"device"}, nil,
+				)
+				c.metricDescs[name] = desc
+			}
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), device)
+		}
+	}
+	return nil
+}
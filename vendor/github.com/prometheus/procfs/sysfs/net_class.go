@@ -60,13 +60,23 @@ type NetClassIface struct {
 }
 
 type NetClassIfaceBondingSlave struct {
-	Name      string
-	MiiStatus int64 // /sys/class/net/<iface>/bonding/mii_status
+	Name             string
+	MiiStatus        int64  // /sys/class/net/<master>/bonding/(lower|slave)_<slave>/bonding_slave/mii_status
+	LinkFailureCount *int64 // /sys/class/net/<master>/bonding/(lower|slave)_<slave>/bonding_slave/link_failure_count
+	PermHWAddr       string // /sys/class/net/<master>/bonding/(lower|slave)_<slave>/bonding_slave/perm_hwaddr
+	QueueID          *int64 // /sys/class/net/<master>/bonding/(lower|slave)_<slave>/bonding_slave/queue_id
 }
 
 type NetClassIfaceBonding struct {
-	Name   string
-	Slaves map[string]NetClassIfaceBondingSlave
+	Name           string
+	Slaves         map[string]NetClassIfaceBondingSlave
+	Mode           string // /sys/class/net/<master>/bonding/mode
+	ActiveSlave    string // /sys/class/net/<master>/bonding/active_slave
+	Primary        string // /sys/class/net/<master>/bonding/primary
+	XmitHashPolicy string // /sys/class/net/<master>/bonding/xmit_hash_policy
+	ArpInterval    *int64 // /sys/class/net/<master>/bonding/arp_interval
+	ArpIPTarget    string // /sys/class/net/<master>/bonding/arp_ip_target
+	MiiMon         *int64 // /sys/class/net/<master>/bonding/miimon
 }
 
 type NetClassBonding map[string]NetClassIfaceBonding
@@ -124,19 +134,88 @@ func (fs FS) NetClassBonding() (NetClassBonding, error) {
 	return netClassBonding, nil
 }
 
-// parseBond parses mii_status per slave of a bond interface
+// readOptionalBondFile reads a file under path and returns its trimmed
+// contents. Missing files (attributes that only apply to certain bonding
+// modes, e.g. primary or arp_interval) are reported via ok == false rather
+// than an error.
+func readOptionalBondFile(path string) (value string, ok bool, err error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return strings.TrimSpace(string(content)), true, nil
+}
+
+// slaveDir returns the directory of a bonding slave below a master's
+// bonding dir, trying both naming schemes used by the kernel over time.
+func slaveDir(path, name string) (string, error) {
+	dir := filepath.Join(path, fmt.Sprintf("lower_%s", name))
+	if _, err := os.Stat(filepath.Join(dir, "bonding_slave")); err == nil {
+		return dir, nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+	// some older? kernels use slave_ prefix
+	return filepath.Join(path, fmt.Sprintf("slave_%s", name)), nil
+}
+
+// parseBond parses the master-level bonding attributes and per-slave state
+// of a bond interface.
 func (ncb NetClassBonding) parseBond(path string) (*NetClassIfaceBonding, error) {
 	content, err := ioutil.ReadFile(filepath.Join(path, "slaves"))
 	if err != nil {
 		return nil, err
 	}
-	bonding := NetClassIfaceBonding{}
+	bonding := NetClassIfaceBonding{
+		Slaves: map[string]NetClassIfaceBondingSlave{},
+	}
+
+	if value, ok, err := readOptionalBondFile(filepath.Join(path, "mode")); err != nil {
+		return nil, err
+	} else if ok {
+		bonding.Mode = value
+	}
+	if value, ok, err := readOptionalBondFile(filepath.Join(path, "active_slave")); err != nil {
+		return nil, err
+	} else if ok {
+		bonding.ActiveSlave = value
+	}
+	if value, ok, err := readOptionalBondFile(filepath.Join(path, "primary")); err != nil {
+		return nil, err
+	} else if ok {
+		bonding.Primary = value
+	}
+	if value, ok, err := readOptionalBondFile(filepath.Join(path, "xmit_hash_policy")); err != nil {
+		return nil, err
+	} else if ok {
+		bonding.XmitHashPolicy = value
+	}
+	if value, ok, err := readOptionalBondFile(filepath.Join(path, "arp_interval")); err != nil {
+		return nil, err
+	} else if ok {
+		bonding.ArpInterval = util.NewValueParser(value).PInt64()
+	}
+	if value, ok, err := readOptionalBondFile(filepath.Join(path, "arp_ip_target")); err != nil {
+		return nil, err
+	} else if ok {
+		bonding.ArpIPTarget = value
+	}
+	if value, ok, err := readOptionalBondFile(filepath.Join(path, "miimon")); err != nil {
+		return nil, err
+	} else if ok {
+		bonding.MiiMon = util.NewValueParser(value).PInt64()
+	}
+
 	for _, name := range strings.Fields(string(content)) {
-		state, err := ioutil.ReadFile(filepath.Join(path, fmt.Sprintf("lower_%s", name), "bonding_slave", "mii_status"))
-		if os.IsNotExist(err) {
-			// some older? kernels use slave_ prefix
-			state, err = ioutil.ReadFile(filepath.Join(path, fmt.Sprintf("slave_%s", name), "bonding_slave", "mii_status"))
+		dir, err := slaveDir(path, name)
+		if err != nil {
+			return nil, err
 		}
+
+		state, err := ioutil.ReadFile(filepath.Join(dir, "bonding_slave", "mii_status"))
 		if err != nil {
 			return nil, err
 		}
@@ -146,11 +225,40 @@ func (ncb NetClassBonding) parseBond(path string) (*NetClassIfaceBonding, error)
 		if strings.TrimSpace(string(state)) == "up" {
 			slave.MiiStatus = 1
 		}
+
+		if value, ok, err := readOptionalBondFile(filepath.Join(dir, "bonding_slave", "link_failure_count")); err != nil {
+			return nil, err
+		} else if ok {
+			slave.LinkFailureCount = util.NewValueParser(value).PInt64()
+		}
+		if value, ok, err := readOptionalBondFile(filepath.Join(dir, "bonding_slave", "perm_hwaddr")); err != nil {
+			return nil, err
+		} else if ok {
+			slave.PermHWAddr = value
+		}
+		if value, ok, err := readOptionalBondFile(filepath.Join(dir, "bonding_slave", "queue_id")); err != nil {
+			return nil, err
+		} else if ok {
+			slave.QueueID = util.NewValueParser(value).PInt64()
+		}
+
 		bonding.Slaves[name] = slave
 	}
 	return &bonding, nil
 }
 
+// NetClassByIface returns info for a single net interface (iface) read from
+// /sys/class/net/<iface>.
+func (fs FS) NetClassByIface(iface string) (*NetClassIface, error) {
+	netClass := NetClass{}
+	interfaceClass, err := netClass.parseNetClassIface(filepath.Join(fs.sys.Path(netclassPath), iface))
+	if err != nil {
+		return nil, err
+	}
+	interfaceClass.Name = iface
+	return interfaceClass, nil
+}
+
 // NetClass returns info for all net interfaces (iface) read from /sys/class/net/<iface>.
 func (fs FS) NetClass() (NetClass, error) {
 	devices, err := fs.NetClassDevices()
@@ -158,14 +266,12 @@ func (fs FS) NetClass() (NetClass, error) {
 		return nil, err
 	}
 
-	path := fs.sys.Path(netclassPath)
 	netClass := NetClass{}
 	for _, deviceDir := range devices {
-		interfaceClass, err := netClass.parseNetClassIface(filepath.Join(path, deviceDir))
+		interfaceClass, err := fs.NetClassByIface(deviceDir)
 		if err != nil {
 			return nil, err
 		}
-		interfaceClass.Name = deviceDir
 		netClass[deviceDir] = *interfaceClass
 	}
 	return netClass, nil
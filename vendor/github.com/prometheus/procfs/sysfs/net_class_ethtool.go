@@ -0,0 +1,359 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package sysfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// ioctl commands/sub-commands from linux/sockios.h and linux/ethtool.h.
+const (
+	siocEthtool = 0x8946
+
+	ethtoolGDrvInfo      = 0x00000003
+	ethtoolGWol          = 0x00000005
+	ethtoolGStrings      = 0x0000001b
+	ethtoolGStats        = 0x0000001d
+	ethtoolGSsetInfo     = 0x00000037
+	ethtoolGLinkSettings = 0x0000004c
+
+	ethStringLen = 32
+	ethSSStats   = 1
+
+	wakeOnLanModes = "pumbagsd"
+)
+
+// NetClassEthtoolInfo holds the subset of ethtool-derived data that is not
+// available from /sys/class/net/<iface> itself.
+type NetClassEthtoolInfo struct {
+	Name                  string
+	Driver                string
+	Version               string
+	FirmwareVersion       string
+	BusInfo               string
+	AutoNegotiate         bool
+	Speed                 *uint64 // bytes/sec, from the current link settings
+	Duplex                string
+	Supported             []string
+	Advertised            []string
+	LinkPartnerAdvertised []string
+	AdvertisedSpeed       *uint64 // bytes/sec, highest speed found in Advertised
+	WakeOnLan             string  // active wake-on-LAN modes, e.g. "g", empty if disabled
+	Stats                 map[string]uint64
+}
+
+type ethtoolDrvInfo struct {
+	cmd         uint32
+	driver      [32]byte
+	version     [32]byte
+	fwVersion   [32]byte
+	busInfo     [32]byte
+	eromVersion [32]byte
+	reserved2   [12]byte
+	nPrivFlags  uint32
+	nStats      uint32
+	testInfoLen uint32
+	eedumpLen   uint32
+	regdumpLen  uint32
+}
+
+// ethtoolLinkSettingsHdr mirrors struct ethtool_link_settings up to the
+// variable-length link mode bitmaps that follow it.
+type ethtoolLinkSettingsHdr struct {
+	cmd                 uint32
+	speed               uint32
+	duplex              uint8
+	port                uint8
+	phyAddress          uint8
+	autoneg             uint8
+	mdioSupport         uint8
+	ethTpMdix           uint8
+	ethTpMdixCtrl       uint8
+	linkModeMasksNWords int8
+	transceiver         uint8
+	masterSlaveCfg      uint8
+	masterSlaveState    uint8
+	reserved1           [1]uint8
+	reserved            [7]uint32
+}
+
+type ethtoolWolInfo struct {
+	cmd       uint32
+	supported uint32
+	wolopts   uint32
+	sopass    [6]byte
+}
+
+// linkModeBits maps a subset of the kernel's ethtool_link_mode_bit_indices
+// to a human-readable mode name and its speed in bits/sec. This does not
+// attempt to cover every mode defined by the kernel, only the common
+// copper/fiber speeds operators are likely to query.
+var linkModeBits = []struct {
+	name string
+	bps  uint64
+}{
+	{"10baseT/Half", 10e6}, {"10baseT/Full", 10e6},
+	{"100baseT/Half", 100e6}, {"100baseT/Full", 100e6},
+	{"1000baseT/Half", 1000e6}, {"1000baseT/Full", 1000e6},
+	{"Autoneg", 0}, {"TP", 0}, {"AUI", 0}, {"MII", 0}, {"FIBRE", 0}, {"BNC", 0},
+	{"10000baseT/Full", 10000e6},
+	{"Pause", 0}, {"Asym_Pause", 0},
+	{"2500baseX/Full", 2500e6},
+	{"Backplane", 0},
+	{"1000baseKX/Full", 1000e6},
+	{"10000baseKX4/Full", 10000e6},
+	{"10000baseKR/Full", 10000e6},
+}
+
+// doEthtoolIoctl issues a SIOCETHTOOL ioctl for iface using the given
+// request struct and returns the raw kernel errno, if any.
+func doEthtoolIoctl(iface string, req unsafe.Pointer) error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var name [unix.IFNAMSIZ]byte
+	copy(name[:], iface)
+
+	ifr := struct {
+		name [unix.IFNAMSIZ]byte
+		data unsafe.Pointer
+	}{name: name, data: req}
+
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), siocEthtool, uintptr(unsafe.Pointer(&ifr)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// isUnsupported reports whether err indicates the ioctl is not available for
+// this device, e.g. for virtual devices like veth, lo, and bridges.
+func isUnsupported(err error) bool {
+	return err == unix.EOPNOTSUPP || err == unix.EINVAL || err == unix.EPERM
+}
+
+func cString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+// NetClassEthtool issues ethtool ioctls (SIOCETHTOOL) for iface and returns
+// driver, link and statistics information that is not exposed under
+// /sys/class/net/<iface>. Devices that do not support a given sub-command
+// (virtual devices such as veth, lo, or bridges) are skipped for that piece
+// of data rather than failing the whole call.
+func (fs FS) NetClassEthtool(iface string) (*NetClassEthtoolInfo, error) {
+	info := &NetClassEthtoolInfo{Name: iface}
+
+	drvInfo := ethtoolDrvInfo{cmd: ethtoolGDrvInfo}
+	if err := doEthtoolIoctl(iface, unsafe.Pointer(&drvInfo)); err != nil {
+		if !isUnsupported(err) {
+			return nil, fmt.Errorf("ETHTOOL_GDRVINFO failed for %s: %w", iface, err)
+		}
+	} else {
+		info.Driver = cString(drvInfo.driver[:])
+		info.Version = cString(drvInfo.version[:])
+		info.FirmwareVersion = cString(drvInfo.fwVersion[:])
+		info.BusInfo = cString(drvInfo.busInfo[:])
+	}
+
+	if err := fs.fillLinkSettings(iface, info); err != nil && !isUnsupported(err) {
+		return nil, err
+	}
+
+	if err := fs.fillStats(iface, info); err != nil && !isUnsupported(err) {
+		return nil, err
+	}
+
+	if err := fs.fillWol(iface, info); err != nil && !isUnsupported(err) {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// fillWol populates info.WakeOnLan via ETHTOOL_GWOL.
+func (fs FS) fillWol(iface string, info *NetClassEthtoolInfo) error {
+	wol := ethtoolWolInfo{cmd: ethtoolGWol}
+	if err := doEthtoolIoctl(iface, unsafe.Pointer(&wol)); err != nil {
+		return err
+	}
+	var modes bytes.Buffer
+	for i, mode := range wakeOnLanModes {
+		if wol.wolopts&(1<<uint(i)) != 0 {
+			modes.WriteRune(mode)
+		}
+	}
+	info.WakeOnLan = modes.String()
+	return nil
+}
+
+// fillLinkSettings populates the auto-negotiation, speed, duplex and
+// supported/advertised mode fields of info via ETHTOOL_GLINKSETTINGS.
+func (fs FS) fillLinkSettings(iface string, info *NetClassEthtoolInfo) error {
+	hdr := ethtoolLinkSettingsHdr{cmd: ethtoolGLinkSettings}
+	// First call with link_mode_masks_nwords == 0 asks the kernel how many
+	// words the variable-length bitmaps need; on success the kernel returns
+	// that count negated rather than an error.
+	if err := doEthtoolIoctl(iface, unsafe.Pointer(&hdr)); err != nil {
+		return err
+	}
+	nwords := -int(hdr.linkModeMasksNWords)
+	if nwords <= 0 {
+		return unix.EOPNOTSUPP
+	}
+
+	buf := make([]byte, int(unsafe.Sizeof(hdr))+3*nwords*4)
+	binary.LittleEndian.PutUint32(buf, ethtoolGLinkSettings)
+	buf[unsafe.Offsetof(hdr.linkModeMasksNWords)] = byte(nwords)
+	if err := doEthtoolIoctl(iface, unsafe.Pointer(&buf[0])); err != nil {
+		return err
+	}
+
+	filled := (*ethtoolLinkSettingsHdr)(unsafe.Pointer(&buf[0]))
+	info.AutoNegotiate = filled.autoneg != 0
+	if filled.speed != 0 && filled.speed != 0xffffffff {
+		speed := uint64(filled.speed) * 1000 * 1000 / 8
+		info.Speed = &speed
+	}
+	switch filled.duplex {
+	case 0:
+		info.Duplex = "half"
+	case 1:
+		info.Duplex = "full"
+	}
+
+	masks := buf[unsafe.Sizeof(hdr):]
+	supported := decodeLinkModeMask(masks[0*nwords*4 : 1*nwords*4])
+	advertised := decodeLinkModeMask(masks[1*nwords*4 : 2*nwords*4])
+	lpAdvertised := decodeLinkModeMask(masks[2*nwords*4 : 3*nwords*4])
+	info.Supported = supported.names
+	info.Advertised = advertised.names
+	info.LinkPartnerAdvertised = lpAdvertised.names
+	if advertised.maxBps > 0 {
+		bytesPerSec := advertised.maxBps / 8
+		info.AdvertisedSpeed = &bytesPerSec
+	}
+	return nil
+}
+
+type decodedModes struct {
+	names  []string
+	maxBps uint64
+}
+
+func decodeLinkModeMask(words []byte) decodedModes {
+	var d decodedModes
+	for bit := 0; bit/32 < len(words)/4; bit++ {
+		word := binary.LittleEndian.Uint32(words[(bit/32)*4 : (bit/32)*4+4])
+		if word&(1<<uint(bit%32)) == 0 {
+			continue
+		}
+		if bit >= len(linkModeBits) {
+			continue
+		}
+		mode := linkModeBits[bit]
+		d.names = append(d.names, mode.name)
+		if mode.bps > d.maxBps {
+			d.maxBps = mode.bps
+		}
+	}
+	return d
+}
+
+// querySSetLen returns the current number of members of the given string
+// set (e.g. ETH_SS_STATS) via ETHTOOL_GSSET_INFO. This is the kernel's
+// authoritative count for a string set; unlike ethtool_drvinfo.n_stats it
+// can't go stale relative to what ETHTOOL_GSTRINGS/ETHTOOL_GSTATS actually
+// copy back, so it's what must be used to size those buffers.
+func querySSetLen(iface string, ssetID uint32) (uint32, error) {
+	// cmd, reserved, sset_mask (8 bytes), one __u32 data slot for ssetID.
+	buf := make([]byte, 4+4+8+4)
+	binary.LittleEndian.PutUint32(buf[0:4], ethtoolGSsetInfo)
+	binary.LittleEndian.PutUint64(buf[8:16], uint64(1)<<ssetID)
+	if err := doEthtoolIoctl(iface, unsafe.Pointer(&buf[0])); err != nil {
+		return 0, err
+	}
+	length, ok := decodeSSetInfoReply(buf, ssetID)
+	if !ok {
+		// The kernel cleared the bit: this string set isn't supported.
+		return 0, nil
+	}
+	return length, nil
+}
+
+// decodeSSetInfoReply extracts the string-set length from a buffer filled
+// in by an ETHTOOL_GSSET_INFO ioctl, as issued by querySSetLen. ok reports
+// whether the kernel left ssetID's bit set in sset_mask, i.e. whether that
+// string set is supported by the device.
+func decodeSSetInfoReply(buf []byte, ssetID uint32) (length uint32, ok bool) {
+	if binary.LittleEndian.Uint64(buf[8:16])&(uint64(1)<<ssetID) == 0 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(buf[16:20]), true
+}
+
+// fillStats populates info.Stats via ETHTOOL_GSTRINGS (ETH_SS_STATS) +
+// ETHTOOL_GSTATS, sized from the authoritative ETHTOOL_GSSET_INFO count.
+func (fs FS) fillStats(iface string, info *NetClassEthtoolInfo) error {
+	nStats, err := querySSetLen(iface, ethSSStats)
+	if err != nil {
+		return err
+	}
+	if nStats == 0 {
+		return nil
+	}
+
+	gstringsHdrSize := 4 + 4 + 4 // cmd, string_set, len
+	gstringsBuf := make([]byte, gstringsHdrSize+int(nStats)*ethStringLen)
+	binary.LittleEndian.PutUint32(gstringsBuf[0:4], ethtoolGStrings)
+	binary.LittleEndian.PutUint32(gstringsBuf[4:8], ethSSStats)
+	binary.LittleEndian.PutUint32(gstringsBuf[8:12], nStats)
+	if err := doEthtoolIoctl(iface, unsafe.Pointer(&gstringsBuf[0])); err != nil {
+		return err
+	}
+
+	gstatsHdrSize := 4 + 4 // cmd, n_stats
+	gstatsBuf := make([]byte, gstatsHdrSize+int(nStats)*8)
+	binary.LittleEndian.PutUint32(gstatsBuf[0:4], ethtoolGStats)
+	binary.LittleEndian.PutUint32(gstatsBuf[4:8], nStats)
+	if err := doEthtoolIoctl(iface, unsafe.Pointer(&gstatsBuf[0])); err != nil {
+		return err
+	}
+
+	stats := make(map[string]uint64, nStats)
+	for i := uint32(0); i < nStats; i++ {
+		nameBytes := gstringsBuf[gstringsHdrSize+int(i)*ethStringLen : gstringsHdrSize+int(i+1)*ethStringLen]
+		name := cString(nameBytes)
+		if name == "" {
+			continue
+		}
+		value := binary.LittleEndian.Uint64(gstatsBuf[gstatsHdrSize+int(i)*8 : gstatsHdrSize+int(i+1)*8])
+		stats[name] = value
+	}
+	info.Stats = stats
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package sysfs
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeLinkModeMask(t *testing.T) {
+	tests := []struct {
+		name       string
+		bits       []int
+		wantNames  []string
+		wantMaxBps uint64
+	}{
+		{
+			name:       "no bits set",
+			bits:       nil,
+			wantNames:  nil,
+			wantMaxBps: 0,
+		},
+		{
+			name:       "single 10baseT bit",
+			bits:       []int{0},
+			wantNames:  []string{"10baseT/Half"},
+			wantMaxBps: 10e6,
+		},
+		{
+			name:       "10000baseT and Pause, picks highest speed",
+			bits:       []int{12, 13},
+			wantNames:  []string{"10000baseT/Full", "Pause"},
+			wantMaxBps: 10000e6,
+		},
+		{
+			name:       "bit beyond known table is ignored",
+			bits:       []int{len(linkModeBits) + 5},
+			wantNames:  nil,
+			wantMaxBps: 0,
+		},
+		{
+			name:       "bit in second word",
+			bits:       []int{32},
+			wantNames:  nil, // bit 32 has no entry in linkModeBits
+			wantMaxBps: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words := make([]byte, 8) // two 32-bit words
+			for _, bit := range tt.bits {
+				word := binary.LittleEndian.Uint32(words[(bit/32)*4 : (bit/32)*4+4])
+				word |= 1 << uint(bit%32)
+				binary.LittleEndian.PutUint32(words[(bit/32)*4:(bit/32)*4+4], word)
+			}
+
+			got := decodeLinkModeMask(words)
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("names = %v, want %v", got.names, tt.wantNames)
+			}
+			if got.maxBps != tt.wantMaxBps {
+				t.Errorf("maxBps = %d, want %d", got.maxBps, tt.wantMaxBps)
+			}
+		})
+	}
+}
+
+func TestDecodeSSetInfoReply(t *testing.T) {
+	const ssetID = uint32(ethSSStats)
+
+	t.Run("bit set reports supported length", func(t *testing.T) {
+		buf := make([]byte, 4+4+8+4)
+		binary.LittleEndian.PutUint64(buf[8:16], uint64(1)<<ssetID)
+		binary.LittleEndian.PutUint32(buf[16:20], 42)
+
+		length, ok := decodeSSetInfoReply(buf, ssetID)
+		if !ok {
+			t.Fatal("ok = false, want true")
+		}
+		if length != 42 {
+			t.Errorf("length = %d, want 42", length)
+		}
+	})
+
+	t.Run("cleared bit reports unsupported", func(t *testing.T) {
+		buf := make([]byte, 4+4+8+4)
+		binary.LittleEndian.PutUint32(buf[16:20], 42)
+
+		_, ok := decodeSSetInfoReply(buf, ssetID)
+		if ok {
+			t.Fatal("ok = true, want false")
+		}
+	})
+}
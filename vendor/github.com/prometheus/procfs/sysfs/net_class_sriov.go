@@ -0,0 +1,147 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package sysfs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// NetClassSRIOVVF holds data about a single SR-IOV virtual function of a
+// physical function interface.
+type NetClassSRIOVVF struct {
+	Index     int    // virtfn<Index> below /sys/class/net/<iface>/device
+	Iface     string // net device name of the VF, if bound to a driver
+	PCIAddr   string // PCI address the virtfn<N> symlink resolves to
+	MAC       string // /sys/class/net/<iface>/device/virtfn<N>/net/<vf>/address
+	VLAN      *int64 // /sys/class/net/<iface>/device/virtfn<N>/vlan, if exposed by the kernel
+	LinkState string // /sys/class/net/<iface>/device/virtfn<N>/net/<vf>/operstate
+}
+
+// NetClassSRIOV holds the SR-IOV capability and VF data of a physical
+// function interface.
+type NetClassSRIOV struct {
+	NumVFs   int64
+	TotalVFs int64
+	VFs      []NetClassSRIOVVF
+}
+
+// NetClassSRIOV returns SR-IOV info for iface by walking
+// /sys/class/net/<iface>/device/sriov_numvfs, sriov_totalvfs, and each
+// virtfn<N> symlink. Devices without SR-IOV support (no sriov_totalvfs
+// file) return a nil NetClassSRIOV and no error.
+func (fs FS) NetClassSRIOV(iface string) (*NetClassSRIOV, error) {
+	devicePath := filepath.Join(fs.sys.Path(netclassPath), iface, "device")
+
+	totalVFs, ok, err := readInt64File(filepath.Join(devicePath, "sriov_totalvfs"))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	numVFs, _, err := readInt64File(filepath.Join(devicePath, "sriov_numvfs"))
+	if err != nil {
+		return nil, err
+	}
+
+	sriov := &NetClassSRIOV{NumVFs: numVFs, TotalVFs: totalVFs}
+
+	for i := int64(0); i < numVFs; i++ {
+		vf, ok, err := parseSRIOVVF(devicePath, int(i))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			sriov.VFs = append(sriov.VFs, *vf)
+		}
+	}
+
+	return sriov, nil
+}
+
+func parseSRIOVVF(devicePath string, index int) (*NetClassSRIOVVF, bool, error) {
+	vfPath := filepath.Join(devicePath, fmt.Sprintf("virtfn%d", index))
+
+	target, err := os.Readlink(vfPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	vf := &NetClassSRIOVVF{
+		Index:   index,
+		PCIAddr: filepath.Base(target),
+	}
+
+	netDir := filepath.Join(vfPath, "net")
+	vfIfaces, err := ioutil.ReadDir(netDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return vf, true, nil
+		}
+		return nil, false, err
+	}
+	if len(vfIfaces) == 0 {
+		return vf, true, nil
+	}
+	vf.Iface = vfIfaces[0].Name()
+	ifaceDir := filepath.Join(netDir, vf.Iface)
+
+	if mac, err := ioutil.ReadFile(filepath.Join(ifaceDir, "address")); err == nil {
+		vf.MAC = strings.TrimSpace(string(mac))
+	} else if !os.IsNotExist(err) {
+		return nil, false, err
+	}
+
+	if state, err := ioutil.ReadFile(filepath.Join(ifaceDir, "operstate")); err == nil {
+		vf.LinkState = strings.TrimSpace(string(state))
+	} else if !os.IsNotExist(err) {
+		return nil, false, err
+	}
+
+	if vlan, ok, err := readInt64File(filepath.Join(vfPath, "vlan")); err != nil {
+		return nil, false, err
+	} else if ok {
+		vf.VLAN = &vlan
+	}
+
+	return vf, true, nil
+}
+
+// readInt64File reads path and parses it as an int64. A missing file is
+// reported via ok == false rather than an error.
+func readInt64File(path string) (value int64, ok bool, err error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	value, err = strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return value, true, nil
+}
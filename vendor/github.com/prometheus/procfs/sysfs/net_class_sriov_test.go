@@ -0,0 +1,129 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package sysfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNetClassSRIOV(t *testing.T) {
+	sysDir, err := ioutil.TempDir("", "sys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sysDir)
+
+	deviceDir := filepath.Join(sysDir, netclassPath, "eth0", "device")
+	if err := os.MkdirAll(deviceDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(deviceDir, "sriov_totalvfs"), []byte("4\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(deviceDir, "sriov_numvfs"), []byte("1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pciDir := filepath.Join(sysDir, "devices", "pci0000:00", "0000:00:01.0")
+	vfNetDir := filepath.Join(pciDir, "net", "eth0v0")
+	if err := os.MkdirAll(vfNetDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(vfNetDir, "address"), []byte("00:11:22:33:44:55\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(vfNetDir, "operstate"), []byte("up\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(pciDir, "vlan"), []byte("42\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(pciDir, filepath.Join(deviceDir, "virtfn0")); err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err := NewFS(sysDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sriov, err := fs.NetClassSRIOV("eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sriov == nil {
+		t.Fatal("NetClassSRIOV returned nil, want non-nil")
+	}
+
+	if sriov.TotalVFs != 4 {
+		t.Errorf("TotalVFs = %d, want 4", sriov.TotalVFs)
+	}
+	if sriov.NumVFs != 1 {
+		t.Errorf("NumVFs = %d, want 1", sriov.NumVFs)
+	}
+	if len(sriov.VFs) != 1 {
+		t.Fatalf("len(VFs) = %d, want 1", len(sriov.VFs))
+	}
+
+	vf := sriov.VFs[0]
+	if vf.Index != 0 {
+		t.Errorf("Index = %d, want 0", vf.Index)
+	}
+	if vf.PCIAddr != "0000:00:01.0" {
+		t.Errorf("PCIAddr = %q, want %q", vf.PCIAddr, "0000:00:01.0")
+	}
+	if vf.Iface != "eth0v0" {
+		t.Errorf("Iface = %q, want %q", vf.Iface, "eth0v0")
+	}
+	if vf.MAC != "00:11:22:33:44:55" {
+		t.Errorf("MAC = %q, want %q", vf.MAC, "00:11:22:33:44:55")
+	}
+	if vf.LinkState != "up" {
+		t.Errorf("LinkState = %q, want %q", vf.LinkState, "up")
+	}
+	if vf.VLAN == nil || *vf.VLAN != 42 {
+		t.Errorf("VLAN = %v, want 42", vf.VLAN)
+	}
+}
+
+func TestNetClassSRIOVUnsupported(t *testing.T) {
+	sysDir, err := ioutil.TempDir("", "sys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sysDir)
+
+	deviceDir := filepath.Join(sysDir, netclassPath, "eth0", "device")
+	if err := os.MkdirAll(deviceDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err := NewFS(sysDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sriov, err := fs.NetClassSRIOV("eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sriov != nil {
+		t.Errorf("NetClassSRIOV = %+v, want nil", sriov)
+	}
+}
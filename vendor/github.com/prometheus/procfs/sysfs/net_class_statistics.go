@@ -0,0 +1,63 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package sysfs
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const netclassStatisticsPath = "statistics"
+
+// NetClassIfaceStatistics holds the counters found in
+// /sys/class/net/<iface>/statistics/*, keyed by file name (e.g. "rx_bytes",
+// "tx_dropped", "collisions").
+type NetClassIfaceStatistics map[string]uint64
+
+// NetClassStatistics reads every regular file in
+// /sys/class/net/<iface>/statistics/ and parses it as a uint64 counter. The
+// file names are taken as-is so newly added kernel counters show up without
+// requiring changes here.
+func (fs FS) NetClassStatistics(iface string) (NetClassIfaceStatistics, error) {
+	path := filepath.Join(fs.sys.Path(netclassPath), iface, netclassStatisticsPath)
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := NetClassIfaceStatistics{}
+	for _, f := range files {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(filepath.Join(path, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			continue
+		}
+		stats[f.Name()] = value
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package sysfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNetClassStatistics(t *testing.T) {
+	sysDir, err := ioutil.TempDir("", "sys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sysDir)
+
+	statsDir := filepath.Join(sysDir, netclassPath, "eth0", netclassStatisticsPath)
+	if err := os.MkdirAll(statsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(statsDir, "rx_bytes"), []byte("1024\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(statsDir, "tx_bytes"), []byte("2048\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A subdirectory under statistics/ is not a kernel counter and must be
+	// skipped rather than failing the read.
+	if err := os.MkdirAll(filepath.Join(statsDir, "not_a_counter"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err := NewFS(sysDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := fs.NetClassStatistics("eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("len(stats) = %d, want 2", len(stats))
+	}
+	if stats["rx_bytes"] != 1024 {
+		t.Errorf("stats[rx_bytes] = %d, want 1024", stats["rx_bytes"])
+	}
+	if stats["tx_bytes"] != 2048 {
+		t.Errorf("stats[tx_bytes] = %d, want 2048", stats["tx_bytes"])
+	}
+}
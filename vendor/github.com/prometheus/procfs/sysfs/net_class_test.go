@@ -0,0 +1,107 @@
+// Copyright 2018 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package sysfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBondFile(t *testing.T, path, name, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(filepath.Join(path, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseBond(t *testing.T) {
+	dir, err := ioutil.TempDir("", "net_class_bonding")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeBondFile(t, dir, "slaves", "eth0 eth1\n")
+	writeBondFile(t, dir, "mode", "active-backup 1\n")
+	writeBondFile(t, dir, "active_slave", "eth0\n")
+	writeBondFile(t, dir, "primary", "eth0\n")
+	writeBondFile(t, dir, "xmit_hash_policy", "layer2 0\n")
+	writeBondFile(t, dir, "arp_interval", "100\n")
+	writeBondFile(t, dir, "arp_ip_target", "192.0.2.1\n")
+	writeBondFile(t, dir, "miimon", "100\n")
+
+	eth0Dir := filepath.Join(dir, "lower_eth0", "bonding_slave")
+	if err := os.MkdirAll(eth0Dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeBondFile(t, eth0Dir, "mii_status", "up\n")
+	writeBondFile(t, eth0Dir, "link_failure_count", "0\n")
+	writeBondFile(t, eth0Dir, "perm_hwaddr", "00:11:22:33:44:55\n")
+	writeBondFile(t, eth0Dir, "queue_id", "0\n")
+
+	// eth1 uses the older slave_ prefix, exercising slaveDir's fallback.
+	eth1Dir := filepath.Join(dir, "slave_eth1", "bonding_slave")
+	if err := os.MkdirAll(eth1Dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeBondFile(t, eth1Dir, "mii_status", "down\n")
+	writeBondFile(t, eth1Dir, "link_failure_count", "3\n")
+	writeBondFile(t, eth1Dir, "perm_hwaddr", "00:11:22:33:44:66\n")
+	writeBondFile(t, eth1Dir, "queue_id", "1\n")
+
+	bonding, err := (NetClassBonding{}).parseBond(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bonding.Mode != "active-backup 1" {
+		t.Errorf("Mode = %q, want %q", bonding.Mode, "active-backup 1")
+	}
+	if bonding.ActiveSlave != "eth0" {
+		t.Errorf("ActiveSlave = %q, want %q", bonding.ActiveSlave, "eth0")
+	}
+	if bonding.ArpInterval == nil || *bonding.ArpInterval != 100 {
+		t.Errorf("ArpInterval = %v, want 100", bonding.ArpInterval)
+	}
+
+	if len(bonding.Slaves) != 2 {
+		t.Fatalf("len(Slaves) = %d, want 2", len(bonding.Slaves))
+	}
+
+	eth0, ok := bonding.Slaves["eth0"]
+	if !ok {
+		t.Fatal("missing slave eth0")
+	}
+	if eth0.MiiStatus != 1 {
+		t.Errorf("eth0.MiiStatus = %d, want 1", eth0.MiiStatus)
+	}
+	if eth0.PermHWAddr != "00:11:22:33:44:55" {
+		t.Errorf("eth0.PermHWAddr = %q, want %q", eth0.PermHWAddr, "00:11:22:33:44:55")
+	}
+
+	eth1, ok := bonding.Slaves["eth1"]
+	if !ok {
+		t.Fatal("missing slave eth1")
+	}
+	if eth1.MiiStatus != 0 {
+		t.Errorf("eth1.MiiStatus = %d, want 0", eth1.MiiStatus)
+	}
+	if eth1.LinkFailureCount == nil || *eth1.LinkFailureCount != 3 {
+		t.Errorf("eth1.LinkFailureCount = %v, want 3", eth1.LinkFailureCount)
+	}
+}